@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/robphoenix/concurrency-made-easy/pkg/taskgroup"
+)
+
+func main() {
+	repos := []string{
+		"fatih/vim-go",
+		"pkg/errors",
+		"rakyll/gotest",
+		"spf13/cobra",
+		"golang/go",
+	}
+	restore(repos)
+}
+
+func fetch(repo string) error {
+	fmt.Printf("fetching repo = %+v\n", repo)
+	return nil
+}
+
+func restore(repos []string) error {
+	var g taskgroup.Group
+	g.SetLimit(4) // four jobs at once
+	for _, repo := range repos {
+		repo := repo
+		g.Go(func() error {
+			return fetch(repo)
+		})
+	}
+	return g.Wait()
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/robphoenix/concurrency-made-easy/pkg/taskgroup"
+)
+
+func main() {
+	repos := []string{
+		"fatih/vim-go",
+		"pkg/errors",
+		"rakyll/gotest",
+		"spf13/cobra",
+		"golang/go",
+	}
+
+	ctx := context.Background()
+	if err := restore(ctx, repos, WithTimeout(2*time.Second)); err != nil {
+		fmt.Println(err)
+	}
+}
+
+// options holds the configurable parts of a restore call.
+type options struct {
+	timeout time.Duration
+}
+
+// Option configures a call to restore.
+type Option func(*options)
+
+// WithTimeout bounds how long a single fetch is allowed to run before its
+// context is cancelled, so one stuck repo can't block the whole pool.
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.timeout = d
+	}
+}
+
+func restore(ctx context.Context, repos []string, opts ...Option) error {
+	cfg := options{timeout: 5 * time.Second}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	g, ctx := taskgroup.WithContext(ctx)
+	g.SetLimit(4) // four jobs at once
+	for _, repo := range repos {
+		repo := repo
+		g.Go(func() error {
+			fctx, cancel := context.WithTimeout(ctx, cfg.timeout)
+			defer cancel()
+			return fetch(fctx, repo)
+		})
+	}
+	return g.Wait()
+}
+
+func fetch(ctx context.Context, repo string) error {
+	select {
+	case <-time.After(100 * time.Millisecond):
+		fmt.Printf("fetching repo = %+v\n", repo)
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
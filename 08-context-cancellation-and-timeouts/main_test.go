@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRestore_WithTimeout_AbortsStuckFetch(t *testing.T) {
+	err := restore(context.Background(), []string{"golang/go"}, WithTimeout(time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("restore() = %v, want error wrapping %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestRestore_WithTimeout_SucceedsWithinBudget(t *testing.T) {
+	err := restore(context.Background(), []string{"golang/go"}, WithTimeout(time.Second))
+	if err != nil {
+		t.Fatalf("restore() = %v, want nil", err)
+	}
+}
+
+func TestRestore_CancelledParentContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := restore(ctx, []string{"fatih/vim-go", "pkg/errors"}, WithTimeout(time.Second))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("restore() = %v, want error wrapping %v", err, context.Canceled)
+	}
+}
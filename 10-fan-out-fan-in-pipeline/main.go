@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Result is the outcome of fetching a single repo.
+type Result struct {
+	Repo     string
+	Err      error
+	Duration time.Duration
+}
+
+func main() {
+	repos := []string{
+		"fatih/vim-go",
+		"pkg/errors",
+		"rakyll/gotest",
+		"spf13/cobra",
+		"golang/go",
+	}
+
+	ctx := context.Background()
+	for r := range Restore(ctx, repos) {
+		if r.Err != nil {
+			fmt.Printf("fetch %s failed after %s: %v\n", r.Repo, r.Duration, r.Err)
+			continue
+		}
+		fmt.Printf("fetched %s in %s\n", r.Repo, r.Duration)
+	}
+}
+
+// Restore fetches each repo using a bounded pool of workers and streams
+// results back on the returned channel as they complete, rather than
+// blocking until every fetch is done. The channel is closed once every
+// repo has been fetched or ctx is cancelled.
+func Restore(ctx context.Context, repos []string) <-chan Result {
+	const workers = 4
+
+	jobs := make(chan string)
+	results := make(chan Result)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go worker(ctx, jobs, results, &wg)
+	}
+
+	go func() {
+		defer close(jobs)
+		for _, repo := range repos {
+			select {
+			case jobs <- repo:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
+}
+
+// worker ranges over jobs, fetching each repo and publishing its Result,
+// until jobs is closed or ctx is cancelled.
+func worker(ctx context.Context, jobs <-chan string, results chan<- Result, wg *sync.WaitGroup) {
+	defer wg.Done()
+	for repo := range jobs {
+		start := time.Now()
+		err := fetch(ctx, repo)
+		select {
+		case results <- Result{Repo: repo, Err: err, Duration: time.Since(start)}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func fetch(ctx context.Context, repo string) error {
+	select {
+	case <-time.After(100 * time.Millisecond):
+		fmt.Printf("fetching repo = %+v\n", repo)
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestRestore_ClosesResultsChannelExactlyOnce(t *testing.T) {
+	repos := []string{"fatih/vim-go", "pkg/errors", "rakyll/gotest"}
+
+	results := Restore(context.Background(), repos)
+
+	got := 0
+	for range results {
+		got++
+	}
+	if got != len(repos) {
+		t.Fatalf("got %d results, want %d", got, len(repos))
+	}
+
+	// The channel must already be closed: a second receive must return
+	// immediately with ok == false, not block or panic.
+	select {
+	case _, ok := <-results:
+		if ok {
+			t.Fatal("received a value from results after it was drained, want channel closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("receiving from a closed results channel blocked")
+	}
+}
+
+func TestRestore_NoGoroutineLeak(t *testing.T) {
+	repos := []string{"fatih/vim-go", "pkg/errors", "rakyll/gotest", "spf13/cobra", "golang/go"}
+
+	before := runtime.NumGoroutine()
+
+	results := Restore(context.Background(), repos)
+	for range results {
+	}
+
+	after := waitForGoroutineCount(t, before)
+	if after > before {
+		t.Fatalf("goroutine count grew from %d to %d after draining results", before, after)
+	}
+}
+
+func TestRestore_ContextCancellationStopsWorkers(t *testing.T) {
+	repos := []string{"fatih/vim-go", "pkg/errors", "rakyll/gotest", "spf13/cobra", "golang/go"}
+
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := Restore(ctx, repos)
+	for range results {
+	}
+
+	after := waitForGoroutineCount(t, before)
+	if after > before {
+		t.Fatalf("goroutine count grew from %d to %d after cancelling ctx", before, after)
+	}
+}
+
+// waitForGoroutineCount polls runtime.NumGoroutine, giving recently
+// finished goroutines a moment to be reaped, and returns the last count
+// it observed.
+func waitForGoroutineCount(t *testing.T, want int) int {
+	t.Helper()
+
+	var got int
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		got = runtime.NumGoroutine()
+		if got <= want {
+			return got
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return got
+}
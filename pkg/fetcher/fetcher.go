@@ -0,0 +1,31 @@
+// Package fetcher defines the Fetcher interface used by the restore
+// examples to retrieve a repo, along with a couple of implementations.
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Fetcher fetches a single repo. What "fetching" means is left entirely
+// to the implementation: printing a line, cloning with git, downloading
+// a tarball, calling the GitHub API, and so on.
+type Fetcher interface {
+	Fetch(ctx context.Context, repo string) error
+}
+
+// StubFetcher is a Fetcher that just reports the repo it was asked to
+// fetch, for use in examples and tests.
+type StubFetcher struct{}
+
+// Fetch implements Fetcher.
+func (StubFetcher) Fetch(ctx context.Context, repo string) error {
+	select {
+	case <-time.After(100 * time.Millisecond):
+		fmt.Printf("fetching repo = %+v\n", repo)
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,168 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStubFetcher_Fetch(t *testing.T) {
+	err := (StubFetcher{}).Fetch(context.Background(), "fatih/vim-go")
+	if err != nil {
+		t.Fatalf("Fetch() = %v, want nil", err)
+	}
+}
+
+func TestStubFetcher_Fetch_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := (StubFetcher{}).Fetch(ctx, "fatih/vim-go")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Fetch() = %v, want error wrapping %v", err, context.Canceled)
+	}
+}
+
+// recordingFetcher wraps another Fetcher and records the order calls
+// arrived in and how many were in flight at once, so tests can assert on
+// both without depending on a real network call.
+type recordingFetcher struct {
+	Fetcher
+
+	mu      sync.Mutex
+	order   []string
+	current int
+	max     int
+}
+
+func (f *recordingFetcher) Fetch(ctx context.Context, repo string) error {
+	f.mu.Lock()
+	f.order = append(f.order, repo)
+	f.current++
+	if f.current > f.max {
+		f.max = f.current
+	}
+	f.mu.Unlock()
+
+	err := f.Fetcher.Fetch(ctx, repo)
+
+	f.mu.Lock()
+	f.current--
+	f.mu.Unlock()
+
+	return err
+}
+
+func TestRecordingFetcher_CallOrderAndConcurrency(t *testing.T) {
+	repos := []string{"fatih/vim-go", "pkg/errors", "rakyll/gotest", "spf13/cobra"}
+
+	f := &recordingFetcher{Fetcher: StubFetcher{}}
+
+	var wg sync.WaitGroup
+	for _, repo := range repos {
+		repo := repo
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := f.Fetch(context.Background(), repo); err != nil {
+				t.Errorf("Fetch(%q) = %v, want nil", repo, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(f.order) != len(repos) {
+		t.Fatalf("recorded %d calls, want %d", len(f.order), len(repos))
+	}
+	if f.max < 2 {
+		t.Fatalf("max concurrent calls = %d, want at least 2 (calls did not overlap)", f.max)
+	}
+}
+
+func TestGitFetcher_Fetch_CleansDestBeforeRetry(t *testing.T) {
+	binDir := t.TempDir()
+	writeRetryingFakeGit(t, binDir)
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	f := GitFetcher{
+		Dest:    t.TempDir(),
+		Retries: 1,
+		Backoff: time.Millisecond,
+		BaseURL: "file:///nonexistent/",
+	}
+	if err := f.Fetch(context.Background(), "some/repo"); err != nil {
+		t.Fatalf("Fetch() = %v, want nil (retry should succeed once dest is cleaned up)", err)
+	}
+}
+
+func TestGitFetcher_Fetch_FailsAfterExhaustingRetries(t *testing.T) {
+	binDir := t.TempDir()
+	writeFailingFakeGit(t, binDir)
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	f := GitFetcher{
+		Dest:    t.TempDir(),
+		Retries: 1,
+		Backoff: time.Millisecond,
+		BaseURL: "file:///nonexistent/",
+	}
+	if err := f.Fetch(context.Background(), "some/repo"); err == nil {
+		t.Fatal("Fetch() = nil, want an error once every attempt fails")
+	}
+}
+
+// writeRetryingFakeGit writes a fake "git" onto binDir's PATH that mimics
+// real git's refusal to clone into a non-empty destination: it fails the
+// first attempt (leaving a partial ".git" behind, as a killed clone
+// would), then fails again if that partial directory wasn't cleaned up
+// before the retry, and only succeeds once it sees a clean destination.
+func writeRetryingFakeGit(t *testing.T, binDir string) {
+	t.Helper()
+
+	counter := filepath.Join(binDir, "attempts")
+	script := fmt.Sprintf(`#!/bin/sh
+dest="$3"
+
+n=0
+if [ -f %q ]; then n=$(cat %q); fi
+n=$((n+1))
+echo "$n" > %q
+
+if [ -d "$dest" ] && [ -n "$(ls -A "$dest" 2>/dev/null)" ]; then
+  echo "fatal: destination path '$dest' already exists and is not an empty directory" >&2
+  exit 128
+fi
+
+if [ "$n" -lt 2 ]; then
+  mkdir -p "$dest/.git"
+  echo partial > "$dest/.git/HEAD"
+  exit 1
+fi
+
+mkdir -p "$dest"
+echo ok > "$dest/marker"
+exit 0
+`, counter, counter, counter)
+
+	writeFakeGit(t, binDir, script)
+}
+
+// writeFailingFakeGit writes a fake "git" onto binDir's PATH that always
+// fails, for exercising the retries-exhausted path.
+func writeFailingFakeGit(t *testing.T, binDir string) {
+	t.Helper()
+	writeFakeGit(t, binDir, "#!/bin/sh\nexit 1\n")
+}
+
+func writeFakeGit(t *testing.T, binDir, script string) {
+	t.Helper()
+	path := filepath.Join(binDir, "git")
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		t.Fatalf("write fake git: %v", err)
+	}
+}
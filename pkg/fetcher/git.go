@@ -0,0 +1,70 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+)
+
+// GitFetcher is a Fetcher that clones repos from GitHub with git, retrying
+// with exponential backoff on failure.
+type GitFetcher struct {
+	// Dest is the directory repos are cloned into, one subdirectory per
+	// repo.
+	Dest string
+	// Retries is the number of times a failed clone is retried before
+	// giving up. Zero means one attempt, with no retries.
+	Retries int
+	// Backoff is the delay before the first retry, doubled after each
+	// subsequent attempt. Zero defaults to one second.
+	Backoff time.Duration
+	// BaseURL is prepended to "<repo>.git" to build the clone URL.
+	// Defaults to "https://github.com/"; tests substitute a local
+	// remote.
+	BaseURL string
+}
+
+// Fetch implements Fetcher by running "git clone" into Dest/repo.
+func (f GitFetcher) Fetch(ctx context.Context, repo string) error {
+	base := f.BaseURL
+	if base == "" {
+		base = "https://github.com/"
+	}
+	url := fmt.Sprintf("%s%s.git", base, repo)
+	dest := filepath.Join(f.Dest, repo)
+
+	attempts := f.Retries + 1
+	backoff := f.Backoff
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			backoff *= 2
+
+			// A killed or interrupted clone can leave a partially
+			// initialised dest behind; git refuses to clone into a
+			// non-empty directory, which would otherwise turn every
+			// retry after the first into an immediate failure.
+			if rmErr := os.RemoveAll(dest); rmErr != nil {
+				return fmt.Errorf("git clone %s: clean up %s before retry: %w", repo, dest, rmErr)
+			}
+		}
+
+		cmd := exec.CommandContext(ctx, "git", "clone", url, dest)
+		if err = cmd.Run(); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("git clone %s: %w", repo, err)
+}
@@ -0,0 +1,86 @@
+// Package taskgroup provides a small, dependency-free Group type for
+// running a collection of goroutines and waiting on their completion,
+// in the spirit of golang.org/x/sync/errgroup.
+package taskgroup
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Group runs a set of tasks in goroutines, optionally bounding how many
+// run concurrently, and collects every error returned by them.
+//
+// The zero value is a valid Group with no limit on concurrency.
+type Group struct {
+	cancel context.CancelFunc
+
+	wg  sync.WaitGroup
+	sem chan struct{}
+
+	mu   sync.Mutex
+	errs []error
+}
+
+// WithContext returns a new Group and an associated Context derived from
+// ctx. The derived Context is cancelled the first time a function passed
+// to Go returns a non-nil error, or the first time Wait returns, whichever
+// occurs first.
+func WithContext(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group{cancel: cancel}, ctx
+}
+
+// SetLimit limits the number of active goroutines in this group to n.
+// A negative value removes the limit. SetLimit must be called before
+// any call to Go.
+func (g *Group) SetLimit(n int) {
+	if n < 0 {
+		g.sem = nil
+		return
+	}
+	g.sem = make(chan struct{}, n)
+}
+
+// Go calls the given function in a new goroutine.
+//
+// Every call to f that returns a non-nil error is recorded, and the
+// Group's associated context (if any) is cancelled on the first one.
+// All recorded errors are returned together by Wait.
+func (g *Group) Go(f func() error) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if g.sem != nil {
+			defer func() { <-g.sem }()
+		}
+
+		if err := f(); err != nil {
+			g.mu.Lock()
+			g.errs = append(g.errs, err)
+			g.mu.Unlock()
+			if g.cancel != nil {
+				g.cancel()
+			}
+		}
+	}()
+}
+
+// Wait blocks until all function calls from the Go method have returned,
+// then returns every recorded error joined together with errors.Join
+// (nil if there were none). The result supports errors.Is and errors.As
+// over each of the joined errors.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel()
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return errors.Join(g.errs...)
+}
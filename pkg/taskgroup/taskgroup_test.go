@@ -0,0 +1,185 @@
+package taskgroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroup_SetLimit(t *testing.T) {
+	const limit = 2
+	const tasks = 10
+
+	var g Group
+	g.SetLimit(limit)
+
+	var (
+		mu      sync.Mutex
+		current int
+		max     int
+	)
+	for i := 0; i < tasks; i++ {
+		g.Go(func() error {
+			mu.Lock()
+			current++
+			if current > max {
+				max = current
+			}
+			mu.Unlock()
+
+			// Give other goroutines a chance to start while this one
+			// is still holding its slot.
+			runtime.Gosched()
+
+			mu.Lock()
+			current--
+			mu.Unlock()
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		t.Fatalf("Wait() = %v, want nil", err)
+	}
+
+	if max > limit {
+		t.Fatalf("observed %d concurrent tasks, want at most %d", max, limit)
+	}
+}
+
+func TestGroup_Wait_WaitGroupAccounting(t *testing.T) {
+	const tasks = 50
+
+	var g Group
+	var count int64
+	for i := 0; i < tasks; i++ {
+		g.Go(func() error {
+			atomic.AddInt64(&count, 1)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		t.Fatalf("Wait() = %v, want nil", err)
+	}
+
+	if got := atomic.LoadInt64(&count); got != tasks {
+		t.Fatalf("completed tasks = %d, want %d", got, tasks)
+	}
+}
+
+func TestGroup_Wait_ErrorPropagation(t *testing.T) {
+	wantErr := errors.New("boom")
+
+	var g Group
+	g.Go(func() error { return nil })
+	g.Go(func() error { return wantErr })
+	g.Go(func() error { return nil })
+
+	if err := g.Wait(); !errors.Is(err, wantErr) {
+		t.Fatalf("Wait() = %v, want error wrapping %v", err, wantErr)
+	}
+}
+
+func TestWithContext_CancelsOnFirstError(t *testing.T) {
+	wantErr := errors.New("boom")
+
+	g, ctx := WithContext(context.Background())
+	g.Go(func() error { return wantErr })
+	g.Go(func() error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+			t.Error("context was not cancelled after a sibling task failed")
+			return nil
+		}
+	})
+
+	if err := g.Wait(); !errors.Is(err, wantErr) {
+		t.Fatalf("Wait() = %v, want error wrapping %v", err, wantErr)
+	}
+	if ctx.Err() == nil {
+		t.Fatal("ctx.Err() = nil, want context to be cancelled")
+	}
+}
+
+func TestWithContext_CancelledOnWait(t *testing.T) {
+	g, ctx := WithContext(context.Background())
+	g.Go(func() error { return nil })
+	if err := g.Wait(); err != nil {
+		t.Fatalf("Wait() = %v, want nil", err)
+	}
+
+	if ctx.Err() == nil {
+		t.Fatal("ctx.Err() = nil, want context to be cancelled once Wait returns")
+	}
+}
+
+// repoError identifies which repo a fetch failure came from, so tests can
+// use errors.As to pick individual failures back out of a joined error.
+type repoError struct {
+	repo string
+}
+
+func (e *repoError) Error() string { return fmt.Sprintf("fetch %s failed", e.repo) }
+
+func TestGroup_Wait_JoinsAllErrors(t *testing.T) {
+	repos := []string{"a/one", "b/two", "c/three"}
+
+	var g Group
+	for _, repo := range repos {
+		repo := repo
+		g.Go(func() error { return &repoError{repo: repo} })
+	}
+
+	err := g.Wait()
+	if err == nil {
+		t.Fatal("Wait() = nil, want an error for every failed repo")
+	}
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("Wait() error %T does not support Unwrap() []error", err)
+	}
+
+	got := make(map[string]bool)
+	for _, e := range joined.Unwrap() {
+		var re *repoError
+		if errors.As(e, &re) {
+			got[re.repo] = true
+		}
+	}
+	for _, repo := range repos {
+		if !got[repo] {
+			t.Errorf("Wait() = %v, missing error for repo %q", err, repo)
+		}
+	}
+}
+
+// TestGroup_Wait_ConcurrentErrorsNoRace exercises many goroutines
+// appending to the shared error slice at once; run with -race to verify
+// the mutex actually guards it.
+func TestGroup_Wait_ConcurrentErrorsNoRace(t *testing.T) {
+	const tasks = 100
+
+	var g Group
+	g.SetLimit(16)
+	for i := 0; i < tasks; i++ {
+		i := i
+		g.Go(func() error {
+			if i%2 == 0 {
+				return fmt.Errorf("task %d failed", i)
+			}
+			return nil
+		})
+	}
+
+	err := g.Wait()
+	if err == nil {
+		t.Fatal("Wait() = nil, want a joined error from the failing tasks")
+	}
+}